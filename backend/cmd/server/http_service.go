@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/travillian/tusk-horn/internal/config"
+	"github.com/travillian/tusk-horn/internal/pkg/database"
+	"github.com/travillian/tusk-horn/internal/pkg/firebase"
+	"github.com/travillian/tusk-horn/internal/pkg/health"
+	"github.com/travillian/tusk-horn/internal/pkg/logger"
+	"github.com/travillian/tusk-horn/internal/pkg/metrics"
+	"github.com/travillian/tusk-horn/internal/server/middleware"
+)
+
+// httpService owns the chi router and the http.Server listening on it.
+type httpService struct {
+	cfg      config.AppConfig
+	rdb      *database.Redis
+	fbClient *firebase.Client
+	cors     *middleware.ReloadableCors
+	health   *health.Registry
+	server   *http.Server
+}
+
+func newHTTPService(cfg config.AppConfig, rdb *database.Redis, fbClient *firebase.Client, cors *middleware.ReloadableCors, healthRegistry *health.Registry) *httpService {
+	return &httpService{cfg: cfg, rdb: rdb, fbClient: fbClient, cors: cors, health: healthRegistry}
+}
+
+func (s *httpService) Name() string { return "http" }
+
+// Start builds the router and serves on cfg.Port until ctx is canceled, at
+// which point Stop takes over via server.Shutdown. server.BaseContext ties
+// every request's context to the supervisor's ctx, so canceling ctx cancels
+// in-flight handlers and dependency calls instead of leaving them running
+// past shutdown.
+func (s *httpService) Start(ctx context.Context) error {
+	r := chi.NewRouter()
+	r.Use(s.cors.Handler)
+	r.Use(chimiddleware.RequestID)
+	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.Tracing)
+	r.Use(middleware.Logger)
+	r.Use(middleware.Auth(s.fbClient))
+	r.Use(chimiddleware.Recoverer)
+	r.Use(middleware.RateLimit(s.rdb.Client, "default", middleware.RateLimitConfig{
+		RPS:   s.cfg.RateLimitRPS,
+		Burst: s.cfg.RateLimitBurst,
+	}))
+	r.Use(middleware.Idempotency(s.rdb.Client, s.cfg.IdempotencyTTL))
+	if s.cfg.MetricsEnabled {
+		r.Use(metrics.Middleware)
+	}
+
+	// /livez: the process is up and serving. /readyz: every dependency's last
+	// background probe succeeded. /healthz: the full per-dependency report.
+	r.Get("/livez", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+
+	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !s.health.Ready() {
+			http.Error(w, "Not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+
+	r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		reports := s.health.Reports()
+
+		status := http.StatusOK
+		for _, report := range reports {
+			if report.Status != health.StatusUp {
+				status = http.StatusServiceUnavailable
+				break
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(reports)
+	})
+
+	if s.cfg.MetricsEnabled {
+		r.Handle("/metrics", metrics.Handler())
+	}
+
+	s.server = &http.Server{
+		Addr:        fmt.Sprintf(":%s", s.cfg.Port),
+		Handler:     r,
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Log.Info("Server listening", "addr", s.server.Addr)
+		errCh <- s.server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("http server: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+func (s *httpService) Stop(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("http server shutdown: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/travillian/tusk-horn/internal/config"
+	"github.com/travillian/tusk-horn/internal/pkg/database"
+	"github.com/travillian/tusk-horn/internal/pkg/events"
+	"github.com/travillian/tusk-horn/internal/pkg/firebase"
+	"github.com/travillian/tusk-horn/internal/pkg/health"
+	"github.com/travillian/tusk-horn/internal/pkg/logger"
+	"github.com/travillian/tusk-horn/internal/pkg/metrics"
+	"github.com/travillian/tusk-horn/internal/pkg/telemetry"
+	"github.com/travillian/tusk-horn/internal/server/middleware"
+)
+
+const (
+	shutdownTimeout     = 10 * time.Second
+	healthProbeInterval = 5 * time.Second
+)
+
+// run builds the service supervisor and blocks until a termination signal is
+// received or a service exits on its own, then stops every service in
+// reverse start order. rootCtx is the parent of the context every service
+// (including per-request HTTP contexts) ultimately derives from, so
+// canceling it cancels in-flight work rather than leaving it orphaned.
+func run(rootCtx context.Context) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	logger.Init(cfg.App.LogLevel, cfg.App.Env)
+	logger.Log.Info("Tusk & Horn Server Starting...", "env", cfg.App.Env, "port", cfg.App.Port)
+
+	ctx, cancel := context.WithCancel(rootCtx)
+	defer cancel()
+
+	pg, err := database.NewPostgres(cfg.Postgres)
+	if err != nil {
+		return fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+
+	rdb, err := database.NewRedis(cfg.Redis)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	eventBus, err := events.NewBus(ctx, cfg.Events)
+	if err != nil {
+		return fmt.Errorf("failed to init event bus: %w", err)
+	}
+
+	fbClient, err := firebase.NewClient(cfg.Firebase.CredentialsPath)
+	if err != nil {
+		logger.Log.Warn("Failed to init Firebase. Auth will fail.", "error", err)
+	}
+
+	shutdownTracer, err := telemetry.InitTracer(ctx, cfg.OTEL)
+	if err != nil {
+		logger.Log.Error("Failed to init tracer", "error", err)
+		shutdownTracer = func(context.Context) error { return nil }
+	}
+
+	corsMW := middleware.NewCors(cfg.App.AllowOrigins)
+
+	healthRegistry := health.NewRegistry(healthProbeInterval)
+	healthRegistry.Register(pg)
+	healthRegistry.Register(rdb)
+	if fbClient != nil {
+		healthRegistry.Register(fbClient)
+	}
+
+	if cfg.App.MetricsEnabled {
+		if err := metrics.RegisterDBStats(pg, rdb); err != nil {
+			logger.Log.Error("Failed to register DB metrics", "error", err)
+		}
+	}
+
+	services := []Service{
+		&postgresService{pg: pg},
+		&redisService{rdb: rdb},
+		&eventsService{bus: eventBus},
+		&firebaseService{client: fbClient},
+		&tracerService{shutdown: shutdownTracer},
+		&healthService{registry: healthRegistry},
+		newHTTPService(cfg.App, rdb, fbClient, corsMW, healthRegistry),
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	for _, svc := range services {
+		svc := svc
+		g.Go(func() error { return svc.Start(gctx) })
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+waitForShutdown:
+	for {
+		select {
+		case sig := <-sigChan:
+			if sig == syscall.SIGHUP {
+				reloadConfig(corsMW)
+				continue
+			}
+			logger.Log.Info("Received shutdown signal", "signal", sig.String())
+			break waitForShutdown
+		case <-gctx.Done():
+			break waitForShutdown
+		}
+	}
+
+	cancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+
+	var stopErr error
+	for i := len(services) - 1; i >= 0; i-- {
+		if err := services[i].Stop(shutdownCtx); err != nil {
+			stopErr = errors.Join(stopErr, fmt.Errorf("%s: %w", services[i].Name(), err))
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		stopErr = errors.Join(stopErr, err)
+	}
+
+	logger.Log.Info("Server exited properly")
+	return stopErr
+}
+
+// reloadConfig re-reads config on SIGHUP and applies the settings that are
+// safe to change without restarting: log level and CORS allowed origins.
+func reloadConfig(corsMW *middleware.ReloadableCors) {
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Log.Error("Failed to reload config", "error", err)
+		return
+	}
+	logger.SetLevel(cfg.App.LogLevel)
+	corsMW.Set(cfg.App.AllowOrigins)
+	logger.Log.Info("Config reloaded", "log_level", cfg.App.LogLevel)
+}
@@ -0,0 +1,23 @@
+package main
+
+import "context"
+
+// Service is a long-running subsystem managed by the supervisor in run.go.
+// Start should block until ctx is canceled, returning any error that caused
+// it to exit early (e.g. the HTTP server failing outside of a graceful
+// shutdown). Stop releases whatever resources Start acquired and should be
+// safe to call even if Start returned an error.
+type Service interface {
+	Name() string
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// waitService is embedded by services whose work is just "stay up until
+// shutdown" once their resource is acquired eagerly before Start is called.
+type waitService struct{}
+
+func (waitService) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
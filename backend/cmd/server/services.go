@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+
+	"github.com/travillian/tusk-horn/internal/pkg/database"
+	"github.com/travillian/tusk-horn/internal/pkg/events"
+	"github.com/travillian/tusk-horn/internal/pkg/firebase"
+	"github.com/travillian/tusk-horn/internal/pkg/health"
+	"github.com/travillian/tusk-horn/internal/pkg/logger"
+)
+
+// postgresService wraps an already-connected Postgres pool so it participates
+// in the supervisor's start/stop lifecycle. The connection itself is made
+// eagerly in run(), before the service group starts, since the HTTP service
+// depends on it being ready.
+type postgresService struct {
+	waitService
+	pg *database.Postgres
+}
+
+func (s *postgresService) Name() string { return "postgres" }
+
+func (s *postgresService) Stop(ctx context.Context) error {
+	s.pg.Close()
+	return nil
+}
+
+// redisService mirrors postgresService for the Redis connection.
+type redisService struct {
+	waitService
+	rdb *database.Redis
+}
+
+func (s *redisService) Name() string { return "redis" }
+
+func (s *redisService) Stop(ctx context.Context) error {
+	s.rdb.Close()
+	return nil
+}
+
+// eventsService owns the JetStream event bus connection.
+type eventsService struct {
+	waitService
+	bus *events.Bus
+}
+
+func (s *eventsService) Name() string { return "events" }
+
+func (s *eventsService) Stop(ctx context.Context) error {
+	return s.bus.Close()
+}
+
+// firebaseService holds the Firebase auth client. A failed connection is
+// logged as a warning in run() rather than treated as fatal, as the previous
+// main.go did; client is then nil for the life of the process (it is not
+// retried), and the Auth middleware passes requests through without a user.
+type firebaseService struct {
+	waitService
+	client *firebase.Client
+}
+
+func (s *firebaseService) Name() string { return "firebase" }
+
+func (s *firebaseService) Stop(ctx context.Context) error { return nil }
+
+// tracerService owns the OTEL tracer provider's shutdown func.
+type tracerService struct {
+	waitService
+	shutdown func(context.Context) error
+}
+
+func (s *tracerService) Name() string { return "tracer" }
+
+func (s *tracerService) Stop(ctx context.Context) error {
+	if err := s.shutdown(ctx); err != nil {
+		logger.Log.Error("Error shutting down tracer", "error", err)
+	}
+	return nil
+}
+
+// healthService runs the background dependency probes backing /readyz and
+// /healthz. Unlike the other services its Start does the actual work rather
+// than just parking, since health.Registry.Run already blocks until ctx is
+// canceled.
+type healthService struct {
+	registry *health.Registry
+}
+
+func (s *healthService) Name() string { return "health" }
+
+func (s *healthService) Start(ctx context.Context) error {
+	s.registry.Run(ctx)
+	return nil
+}
+
+func (s *healthService) Stop(ctx context.Context) error { return nil }
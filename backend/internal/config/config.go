@@ -0,0 +1,235 @@
+// Package config loads process configuration from the environment. Every
+// subsystem takes only its own part of the config (PostgresConfig,
+// RedisConfig, ..., or a single field such as the Firebase credentials path)
+// rather than the whole Config, so a constructor's signature documents
+// exactly what it depends on.
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is the fully loaded process configuration, grouped by subsystem.
+type Config struct {
+	App      AppConfig
+	Postgres PostgresConfig
+	Redis    RedisConfig
+	Events   EventsConfig
+	Firebase FirebaseConfig
+	OTEL     OTELConfig
+}
+
+// AppConfig holds settings for the HTTP service itself rather than any one
+// dependency.
+type AppConfig struct {
+	Env          string
+	LogLevel     string
+	Port         string
+	AllowOrigins []string
+
+	// MetricsEnabled turns on the metrics HTTP middleware and the /metrics
+	// Prometheus endpoint.
+	MetricsEnabled bool
+
+	// RateLimitRPS and RateLimitBurst configure the default per-subject
+	// token bucket applied to every request.
+	RateLimitRPS   float64
+	RateLimitBurst int
+
+	// IdempotencyTTL is how long a cached response for an Idempotency-Key
+	// is replayed before the key expires and the next request re-executes.
+	IdempotencyTTL time.Duration
+}
+
+// PostgresConfig configures the pooled Postgres connection returned by
+// database.NewPostgres.
+type PostgresConfig struct {
+	// URI, when set, is used as-is as a full postgres://... connection
+	// string and takes precedence over the discrete fields below.
+	URI string
+
+	Host               string
+	Port               string
+	User               string
+	Password           string
+	DBName             string
+	SSLMode            string
+	MaxConnections     int
+	MaxIdleConnections int
+}
+
+// RedisConfig configures the pooled Redis client returned by
+// database.NewRedis.
+type RedisConfig struct {
+	// URI, when set, is used as-is as a full redis://... or rediss://...
+	// connection string and takes precedence over the discrete fields below.
+	URI string
+
+	Host       string
+	Port       string
+	Password   string
+	DB         int
+	PoolSize   int
+	MaxRetries int
+}
+
+// EventsConfig configures the JetStream-backed domain event bus returned by
+// events.NewBus.
+type EventsConfig struct {
+	URL        string
+	StreamName string
+	Subjects   []string
+	MaxAge     time.Duration
+	MaxBytes   int64
+	MaxDeliver int
+	AckWait    time.Duration
+	// DLQSubject, if set, receives messages that exhaust MaxDeliver instead
+	// of being silently terminated.
+	DLQSubject string
+}
+
+// FirebaseConfig configures the Firebase Admin SDK client.
+type FirebaseConfig struct {
+	CredentialsPath string
+}
+
+// OTELConfig configures the OpenTelemetry tracer provider.
+type OTELConfig struct {
+	Enabled              bool
+	ServiceName          string
+	ExporterOTLPEndpoint string
+}
+
+// Load reads Config from the environment, applying defaults for anything
+// unset.
+func Load() (Config, error) {
+	cfg := Config{
+		App: AppConfig{
+			Env:            getEnv("APP_ENV", "development"),
+			LogLevel:       getEnv("APP_LOG_LEVEL", "info"),
+			Port:           getEnv("APP_PORT", "8080"),
+			AllowOrigins:   getEnvStringSlice("APP_ALLOW_ORIGINS", []string{"*"}),
+			MetricsEnabled: getEnvBool("APP_METRICS_ENABLED", true),
+			RateLimitRPS:   getEnvFloat("APP_RATE_LIMIT_RPS", 10),
+			RateLimitBurst: getEnvInt("APP_RATE_LIMIT_BURST", 20),
+			IdempotencyTTL: getEnvDuration("APP_IDEMPOTENCY_TTL", 24*time.Hour),
+		},
+		Postgres: PostgresConfig{
+			URI:                getEnv("POSTGRES_URI", ""),
+			Host:               getEnv("POSTGRES_HOST", "localhost"),
+			Port:               getEnv("POSTGRES_PORT", "5432"),
+			User:               getEnv("POSTGRES_USER", "postgres"),
+			Password:           getEnv("POSTGRES_PASSWORD", ""),
+			DBName:             getEnv("POSTGRES_DB", "postgres"),
+			SSLMode:            getEnv("POSTGRES_SSLMODE", "disable"),
+			MaxConnections:     getEnvInt("POSTGRES_MAX_CONNECTIONS", 10),
+			MaxIdleConnections: getEnvInt("POSTGRES_MAX_IDLE_CONNECTIONS", 2),
+		},
+		Redis: RedisConfig{
+			URI:        getEnv("REDIS_URI", ""),
+			Host:       getEnv("REDIS_HOST", "localhost"),
+			Port:       getEnv("REDIS_PORT", "6379"),
+			Password:   getEnv("REDIS_PASSWORD", ""),
+			DB:         getEnvInt("REDIS_DB", 0),
+			PoolSize:   getEnvInt("REDIS_POOL_SIZE", 10),
+			MaxRetries: getEnvInt("REDIS_MAX_RETRIES", 3),
+		},
+		Events: EventsConfig{
+			URL:        getEnv("EVENTS_URL", "nats://localhost:4222"),
+			StreamName: getEnv("EVENTS_STREAM_NAME", "domain-events"),
+			Subjects:   getEnvStringSlice("EVENTS_SUBJECTS", []string{"domain-events.>"}),
+			MaxAge:     getEnvDuration("EVENTS_MAX_AGE", 7*24*time.Hour),
+			MaxBytes:   int64(getEnvInt("EVENTS_MAX_BYTES", 0)),
+			MaxDeliver: getEnvInt("EVENTS_MAX_DELIVER", 5),
+			AckWait:    getEnvDuration("EVENTS_ACK_WAIT", 30*time.Second),
+			DLQSubject: getEnv("EVENTS_DLQ_SUBJECT", ""),
+		},
+		Firebase: FirebaseConfig{
+			CredentialsPath: getEnv("FIREBASE_CREDENTIALS_PATH", ""),
+		},
+		OTEL: OTELConfig{
+			Enabled:              getEnvBool("OTEL_ENABLED", false),
+			ServiceName:          getEnv("OTEL_SERVICE_NAME", "tusk-horn"),
+			ExporterOTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+		},
+	}
+
+	return cfg, nil
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return fallback
+	}
+	return f
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+func getEnvStringSlice(key string, fallback []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	parts := strings.Split(v, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	if len(out) == 0 {
+		return fallback
+	}
+	return out
+}
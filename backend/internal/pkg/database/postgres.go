@@ -12,28 +12,49 @@ import (
 
 type Postgres struct {
 	Pool *pgxpool.Pool
+
+	registry *Registry
+	uri      string
 }
 
 func NewPostgres(cfg config.PostgresConfig) (*Postgres, error) {
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.User,
-		cfg.Password,
-		cfg.Host,
-		cfg.Port,
-		cfg.DBName,
-		cfg.SSLMode,
-	)
+	return newPostgres(DefaultRegistry, cfg)
+}
+
+func newPostgres(registry *Registry, cfg config.PostgresConfig) (*Postgres, error) {
+	dsn := postgresURI(cfg)
+
+	registry.mu.Lock()
+	if entry, ok := registry.postgres[dsn]; ok {
+		entry.refs++
+		registry.mu.Unlock()
+		return entry.conn, nil
+	}
+	registry.mu.Unlock()
 
 	pgConfig, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	pgConfig.MaxConns = int32(cfg.MaxConnections)
-	pgConfig.MinConns = int32(cfg.MaxIdleConnections)
+	// cfg.MaxConnections/MaxIdleConnections only apply when there's no full
+	// URI: a caller that hands us a complete postgres://...?pool_max_conns=50
+	// URI means it as-is, and config.Load's defaults for the discrete fields
+	// shouldn't silently clobber whatever the URI's query string set.
+	if cfg.URI == "" {
+		if cfg.MaxConnections > 0 {
+			pgConfig.MaxConns = int32(cfg.MaxConnections)
+		}
+		if cfg.MaxIdleConnections > 0 {
+			pgConfig.MinConns = int32(cfg.MaxIdleConnections)
+		}
+	}
 	pgConfig.MaxConnLifetime = time.Hour
 	pgConfig.MaxConnIdleTime = 30 * time.Minute
 
+	// The dial and ping happen outside the lock so a slow or unreachable
+	// Postgres doesn't stall unrelated NewPostgres/NewRedis callers that
+	// share this registry.
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -43,15 +64,82 @@ func NewPostgres(cfg config.PostgresConfig) (*Postgres, error) {
 	}
 
 	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("failed to ping postgres: %w", err)
 	}
 
 	log.Println("Connected to PostgreSQL")
-	return &Postgres{Pool: pool}, nil
+	pg := &Postgres{Pool: pool, registry: registry, uri: dsn}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	// Another caller may have raced us and inserted the same dsn while we
+	// were dialing; prefer their connection and close the one we just made
+	// rather than leaking it.
+	if entry, ok := registry.postgres[dsn]; ok {
+		entry.refs++
+		pool.Close()
+		return entry.conn, nil
+	}
+
+	registry.postgres[dsn] = &postgresEntry{conn: pg, refs: 1}
+	return pg, nil
+}
+
+// postgresURI returns the connection URI for cfg. If cfg.URI is already a
+// full postgres://... connection string (e.g. carrying sslmode=verify-full or
+// other libpq query params), it is used as-is and also serves as the
+// registry's dedup key; otherwise a URI is assembled from the discrete
+// host/port/user fields for backward compatibility.
+func postgresURI(cfg config.PostgresConfig) string {
+	if cfg.URI != "" {
+		return cfg.URI
+	}
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		cfg.User,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port,
+		cfg.DBName,
+		cfg.SSLMode,
+	)
 }
 
+// Close releases this handle's reference to the pooled connection. The
+// underlying pgxpool.Pool is only closed once every caller sharing the same
+// registry entry has released it.
 func (p *Postgres) Close() {
-	if p.Pool != nil {
+	if p.Pool == nil {
+		return
+	}
+	if p.registry == nil {
 		p.Pool.Close()
+		return
 	}
+
+	p.registry.mu.Lock()
+	defer p.registry.mu.Unlock()
+
+	entry, ok := p.registry.postgres[p.uri]
+	if !ok {
+		p.Pool.Close()
+		return
+	}
+
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+
+	delete(p.registry.postgres, p.uri)
+	p.Pool.Close()
+}
+
+// Name identifies this dependency in health.Registry reports.
+func (p *Postgres) Name() string { return "postgres" }
+
+// Check satisfies health.Checker by pinging the pool.
+func (p *Postgres) Check(ctx context.Context) error {
+	return p.Pool.Ping(ctx)
 }
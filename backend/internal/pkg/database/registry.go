@@ -0,0 +1,41 @@
+package database
+
+import "sync"
+
+// Registry is a process-wide cache of pooled Postgres and Redis clients keyed
+// by their connection URI. A configured URI is used as the key verbatim, so
+// two differently written URIs for the same server (e.g. with and without
+// the default port) are not merged and each gets its own pool; without one,
+// the key is built from the discrete config fields. Subsystems that call NewPostgres or
+// NewRedis for the same target (e.g. cache, sessions, and queues all pointing
+// at the same Redis instance) share a single pool instead of each opening its
+// own, and the underlying pool is only closed once every caller has released
+// it via Close.
+type Registry struct {
+	mu       sync.Mutex
+	postgres map[string]*postgresEntry
+	redis    map[string]*redisEntry
+}
+
+type postgresEntry struct {
+	conn *Postgres
+	refs int
+}
+
+type redisEntry struct {
+	conn *Redis
+	refs int
+}
+
+// DefaultRegistry is the registry used by NewPostgres and NewRedis. It is a
+// package-level var rather than a singleton accessor so tests can swap in a
+// fresh Registry without affecting other packages.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty connection registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		postgres: make(map[string]*postgresEntry),
+		redis:    make(map[string]*redisEntry),
+	}
+}
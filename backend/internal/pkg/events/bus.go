@@ -0,0 +1,162 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/travillian/tusk-horn/internal/config"
+)
+
+// Handler processes a single delivered message. Returning an error leaves the
+// message un-acked so JetStream redelivers it (subject to MaxDeliver);
+// returning nil acks it.
+type Handler func(ctx context.Context, payload []byte) error
+
+// Bus is a JetStream-backed publisher/subscriber for domain events, giving
+// the service an async backbone for user/domain events instead of relying
+// only on HTTP.
+type Bus struct {
+	cfg  config.EventsConfig
+	conn *nats.Conn
+	js   jetstream.JetStream
+
+	mu          sync.Mutex
+	consumeCtxs []jetstream.ConsumeContext
+}
+
+// NewBus connects to NATS, provisions the configured stream (idempotently),
+// and returns a ready-to-use Bus.
+func NewBus(ctx context.Context, cfg config.EventsConfig) (*Bus, error) {
+	conn, err := nats.Connect(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to init jetstream: %w", err)
+	}
+
+	b := &Bus{cfg: cfg, conn: conn, js: js}
+	if err := b.provisionStream(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to provision stream %q: %w", cfg.StreamName, err)
+	}
+
+	return b, nil
+}
+
+func (b *Bus) provisionStream(ctx context.Context) error {
+	streamCfg := jetstream.StreamConfig{
+		Name:      b.cfg.StreamName,
+		Subjects:  b.cfg.Subjects,
+		Retention: jetstream.LimitsPolicy,
+		MaxAge:    b.cfg.MaxAge,
+		MaxBytes:  b.cfg.MaxBytes,
+	}
+
+	if _, err := b.js.Stream(ctx, b.cfg.StreamName); err != nil {
+		if errors.Is(err, jetstream.ErrStreamNotFound) {
+			_, err := b.js.CreateStream(ctx, streamCfg)
+			return err
+		}
+		return err
+	}
+
+	_, err := b.js.UpdateStream(ctx, streamCfg)
+	return err
+}
+
+// Publish sends payload on subject, waiting for the broker's ack that it was
+// persisted to the stream.
+func (b *Bus) Publish(ctx context.Context, subject string, payload []byte) error {
+	if _, err := b.js.Publish(ctx, subject, payload); err != nil {
+		return fmt.Errorf("failed to publish to %q: %w", subject, err)
+	}
+	return nil
+}
+
+// Subscribe creates (or reuses) a pull-based durable consumer named
+// durableName, filtered to subject, and delivers messages to handler.
+// Messages handler returns an error for are Nak'd for redelivery; once a
+// message has been delivered cfg.MaxDeliver times it is forwarded to
+// cfg.DLQSubject (if set) before being terminated.
+func (b *Bus) Subscribe(ctx context.Context, subject, durableName string, handler Handler) error {
+	consumer, err := b.js.CreateOrUpdateConsumer(ctx, b.cfg.StreamName, jetstream.ConsumerConfig{
+		Durable:       durableName,
+		FilterSubject: subject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		MaxDeliver:    b.cfg.MaxDeliver,
+		AckWait:       b.cfg.AckWait,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create consumer %q: %w", durableName, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		b.deliver(ctx, msg, handler)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start consuming %q: %w", durableName, err)
+	}
+
+	b.mu.Lock()
+	b.consumeCtxs = append(b.consumeCtxs, consumeCtx)
+	b.mu.Unlock()
+
+	return nil
+}
+
+func (b *Bus) deliver(ctx context.Context, msg jetstream.Msg, handler Handler) {
+	if err := handler(ctx, msg.Data()); err != nil {
+		if b.cfg.DLQSubject != "" && deliveredTooManyTimes(msg, b.cfg.MaxDeliver) {
+			// Publish through JetStream, not core NATS, so the dead-lettered
+			// payload is only Term()'d off the original stream once the
+			// broker has acked that it's durably persisted on DLQSubject.
+			if _, err := b.js.Publish(ctx, b.cfg.DLQSubject, msg.Data()); err != nil {
+				_ = msg.Nak()
+				return
+			}
+			_ = msg.Term()
+			return
+		}
+		_ = msg.Nak()
+		return
+	}
+	_ = msg.Ack()
+}
+
+func deliveredTooManyTimes(msg jetstream.Msg, maxDeliver int) bool {
+	meta, err := msg.Metadata()
+	if err != nil || maxDeliver <= 0 {
+		return false
+	}
+	return meta.NumDelivered >= uint64(maxDeliver)
+}
+
+// Close stops every active consumer and drains the NATS connection so
+// in-flight publishes and acks complete before returning.
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	for _, c := range b.consumeCtxs {
+		c.Stop()
+	}
+	b.consumeCtxs = nil
+	b.mu.Unlock()
+
+	if err := b.conn.Drain(); err != nil {
+		return fmt.Errorf("failed to drain nats connection: %w", err)
+	}
+	// Drain only starts draining; the connection closes itself once it is
+	// done (or its drain timeout passes), so wait for that here.
+	for !b.conn.IsClosed() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
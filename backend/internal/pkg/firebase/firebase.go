@@ -0,0 +1,46 @@
+package firebase
+
+import (
+	"context"
+	"fmt"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/api/option"
+)
+
+// Client wraps the Firebase Admin SDK's auth client so the rest of the
+// service only depends on the narrow surface it actually uses.
+type Client struct {
+	Auth *auth.Client
+}
+
+// NewClient initializes the Firebase Admin SDK from the service account
+// credentials at credentialsPath and returns a ready-to-use Client.
+func NewClient(credentialsPath string) (*Client, error) {
+	app, err := firebase.NewApp(context.Background(), nil, option.WithCredentialsFile(credentialsPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to init firebase app: %w", err)
+	}
+
+	authClient, err := app.Auth(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to init firebase auth client: %w", err)
+	}
+
+	return &Client{Auth: authClient}, nil
+}
+
+// Name identifies this dependency in health.Registry reports.
+func (c *Client) Name() string { return "firebase" }
+
+// Check satisfies health.Checker with a lightweight Auth Admin API call. A
+// "user not found" response still proves the credentials and network path
+// work, so only any other error is treated as the dependency being down.
+func (c *Client) Check(ctx context.Context) error {
+	_, err := c.Auth.GetUser(ctx, "__healthcheck__")
+	if err != nil && !auth.IsUserNotFound(err) {
+		return fmt.Errorf("firebase auth check failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Status is the outcome of a single dependency probe.
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Checker is implemented by anything the service depends on that can report
+// its own health, e.g. *database.Postgres, *database.Redis, *firebase.Client.
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// Report is the cached outcome of the most recent probe of one Checker.
+type Report struct {
+	Status    Status    `json:"status"`
+	LatencyMs int64     `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// probeTimeout bounds a single Checker.Check call so one wedged dependency
+// can't stall the whole probe round.
+const probeTimeout = 2 * time.Second
+
+// Registry runs periodic background probes against its registered Checkers
+// and caches the results, so Ready and Reports return immediately instead of
+// dialing dependencies on every /readyz or /healthz request.
+type Registry struct {
+	interval time.Duration
+
+	mu       sync.RWMutex
+	checkers []Checker
+	reports  map[string]Report
+}
+
+// NewRegistry creates a Registry that probes its Checkers every interval.
+func NewRegistry(interval time.Duration) *Registry {
+	return &Registry{
+		interval: interval,
+		reports:  make(map[string]Report),
+	}
+}
+
+// Register adds c to the set of Checkers probed by Run.
+func (r *Registry) Register(c Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkers = append(r.checkers, c)
+}
+
+// Run probes every registered Checker immediately, then again every
+// r.interval, until ctx is canceled.
+func (r *Registry) Run(ctx context.Context) {
+	r.probeAll(ctx)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.probeAll(ctx)
+		}
+	}
+}
+
+func (r *Registry) probeAll(ctx context.Context) {
+	r.mu.RLock()
+	checkers := append([]Checker(nil), r.checkers...)
+	r.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, c := range checkers {
+		wg.Add(1)
+		go func(c Checker) {
+			defer wg.Done()
+			r.probe(ctx, c)
+		}(c)
+	}
+	wg.Wait()
+}
+
+func (r *Registry) probe(ctx context.Context, c Checker) {
+	probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Check(probeCtx)
+	report := Report{
+		LatencyMs: time.Since(start).Milliseconds(),
+		CheckedAt: time.Now(),
+		Status:    StatusUp,
+	}
+	if err != nil {
+		report.Status = StatusDown
+		report.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	r.reports[c.Name()] = report
+	r.mu.Unlock()
+}
+
+// Ready reports whether every registered Checker's last probe succeeded.
+// It returns false until every Checker has reported at least once.
+func (r *Registry) Ready() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.reports) < len(r.checkers) {
+		return false
+	}
+	for _, report := range r.reports {
+		if report.Status != StatusUp {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports returns a snapshot of the most recent report per Checker, keyed by
+// Checker.Name().
+func (r *Registry) Reports() map[string]Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reports := make(map[string]Report, len(r.reports))
+	for name, report := range r.reports {
+		reports[name] = report
+	}
+	return reports
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// Log is the process-wide base logger, set up by Init. Code with no
+// request-scoped logger available (startup, background services) logs
+// through this directly; request handlers and repositories should prefer
+// FromContext so their lines carry request correlation fields.
+var Log *slog.Logger
+
+var level = &slog.LevelVar{}
+
+type loggerContextKey struct{}
+
+// Init builds the base logger for the given log level ("debug", "info",
+// "warn", "error") and environment. In "development" logs are rendered as
+// human-readable text; anything else gets JSON for log aggregators.
+func Init(logLevel, env string) {
+	level.Set(parseLevel(logLevel))
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	if env == "development" {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+
+	Log = slog.New(handler)
+}
+
+// SetLevel changes the base logger's level in place, without rebuilding the
+// handler, so a SIGHUP config reload can adjust verbosity at runtime.
+func SetLevel(logLevel string) {
+	level.Set(parseLevel(logLevel))
+}
+
+func parseLevel(logLevel string) slog.Level {
+	switch logLevel {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithContext returns a copy of ctx carrying l, retrievable via FromContext.
+// The Logger middleware uses this to attach a request-scoped logger.
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext returns the request-scoped logger attached by the Logger
+// middleware, or the base Log if ctx carries none.
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return Log
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/travillian/tusk-horn/internal/pkg/database"
+)
+
+var (
+	pgAcquiredDesc = prometheus.NewDesc("postgres_pool_acquired_conns", "Currently acquired postgres connections.", nil, nil)
+	pgIdleDesc     = prometheus.NewDesc("postgres_pool_idle_conns", "Currently idle postgres connections.", nil, nil)
+	pgTotalDesc    = prometheus.NewDesc("postgres_pool_total_conns", "Total postgres connections in the pool.", nil, nil)
+	pgMaxDesc      = prometheus.NewDesc("postgres_pool_max_conns", "Configured maximum postgres connections.", nil, nil)
+
+	redisHitsDesc     = prometheus.NewDesc("redis_pool_hits_total", "Times a free connection was found in the redis pool.", nil, nil)
+	redisMissesDesc   = prometheus.NewDesc("redis_pool_misses_total", "Times a free connection was not found in the redis pool.", nil, nil)
+	redisTimeoutsDesc = prometheus.NewDesc("redis_pool_timeouts_total", "Times a wait for a redis connection timed out.", nil, nil)
+	redisTotalDesc    = prometheus.NewDesc("redis_pool_total_conns", "Total connections in the redis pool.", nil, nil)
+	redisIdleDesc     = prometheus.NewDesc("redis_pool_idle_conns", "Idle connections in the redis pool.", nil, nil)
+	redisStaleDesc    = prometheus.NewDesc("redis_pool_stale_conns", "Stale connections evicted from the redis pool.", nil, nil)
+)
+
+// dbStatsCollector reads pgxpool.Stat() and the Redis client's PoolStats()
+// fresh on every scrape rather than tracking them as accumulated counters, so
+// gauges always reflect the pool's current state.
+type dbStatsCollector struct {
+	pg  *database.Postgres
+	rdb *database.Redis
+}
+
+// RegisterDBStats registers a collector exposing Postgres and Redis
+// connection pool stats with prometheus's default registry.
+func RegisterDBStats(pg *database.Postgres, rdb *database.Redis) error {
+	return prometheus.Register(&dbStatsCollector{pg: pg, rdb: rdb})
+}
+
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pgAcquiredDesc
+	ch <- pgIdleDesc
+	ch <- pgTotalDesc
+	ch <- pgMaxDesc
+	ch <- redisHitsDesc
+	ch <- redisMissesDesc
+	ch <- redisTimeoutsDesc
+	ch <- redisTotalDesc
+	ch <- redisIdleDesc
+	ch <- redisStaleDesc
+}
+
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.pg != nil && c.pg.Pool != nil {
+		stat := c.pg.Pool.Stat()
+		ch <- prometheus.MustNewConstMetric(pgAcquiredDesc, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+		ch <- prometheus.MustNewConstMetric(pgIdleDesc, prometheus.GaugeValue, float64(stat.IdleConns()))
+		ch <- prometheus.MustNewConstMetric(pgTotalDesc, prometheus.GaugeValue, float64(stat.TotalConns()))
+		ch <- prometheus.MustNewConstMetric(pgMaxDesc, prometheus.GaugeValue, float64(stat.MaxConns()))
+	}
+
+	if c.rdb != nil && c.rdb.Client != nil {
+		stat := c.rdb.Client.PoolStats()
+		ch <- prometheus.MustNewConstMetric(redisHitsDesc, prometheus.CounterValue, float64(stat.Hits))
+		ch <- prometheus.MustNewConstMetric(redisMissesDesc, prometheus.CounterValue, float64(stat.Misses))
+		ch <- prometheus.MustNewConstMetric(redisTimeoutsDesc, prometheus.CounterValue, float64(stat.Timeouts))
+		ch <- prometheus.MustNewConstMetric(redisTotalDesc, prometheus.GaugeValue, float64(stat.TotalConns))
+		ch <- prometheus.MustNewConstMetric(redisIdleDesc, prometheus.GaugeValue, float64(stat.IdleConns))
+		ch <- prometheus.MustNewConstMetric(redisStaleDesc, prometheus.GaugeValue, float64(stat.StaleConns))
+	}
+}
@@ -0,0 +1,49 @@
+// Package telemetry configures the process-wide OpenTelemetry tracer
+// provider backing middleware.Tracing's per-request spans.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+
+	"github.com/travillian/tusk-horn/internal/config"
+)
+
+// InitTracer configures the global tracer provider to export spans to
+// cfg.ExporterOTLPEndpoint over OTLP/gRPC and returns a shutdown func that
+// flushes and closes the exporter. If cfg.Enabled is false, the default
+// no-op global provider is left in place and InitTracer returns a no-op
+// shutdown func, so middleware.Tracing's spans are simply discarded rather
+// than requiring a reachable collector in dev/test.
+func InitTracer(ctx context.Context, cfg config.OTELConfig) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(cfg.ExporterOTLPEndpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OTEL resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/travillian/tusk-horn/internal/pkg/firebase"
+	"github.com/travillian/tusk-horn/internal/pkg/logger"
+)
+
+const (
+	authHeader   = "Authorization"
+	bearerPrefix = "Bearer "
+)
+
+// Auth verifies the Firebase ID token on the Authorization header, if
+// present, and records the resulting UID via SetUserID, so Logger's
+// access-log line includes it. It also rebuilds the request-scoped logger
+// in context to carry user_id, so handlers and repositories logging via
+// logger.FromContext mid-request get it too.
+//
+// A missing or unverifiable token isn't rejected here: this middleware only
+// wires identity into logging and request scope, it doesn't enforce that a
+// route requires authentication.
+func Auth(fbClient *firebase.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if fbClient == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			raw := r.Header.Get(authHeader)
+			if !strings.HasPrefix(raw, bearerPrefix) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, err := fbClient.Auth.VerifyIDToken(r.Context(), strings.TrimPrefix(raw, bearerPrefix))
+			if err != nil {
+				logger.FromContext(r.Context()).Warn("Failed to verify Firebase ID token", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			SetUserID(ctx, token.UID)
+			ctx = logger.WithContext(ctx, logger.FromContext(ctx).With("user_id", token.UID))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -2,17 +2,45 @@ package middleware
 
 import (
 	"net/http"
+	"sync/atomic"
 
 	"github.com/go-chi/cors"
 )
 
+// Cors returns a static CORS middleware for the given allowed origins.
 func Cors(allowedOrigins []string) func(http.Handler) http.Handler {
-	return cors.Handler(cors.Options{
-		AllowedOrigins:   allowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300, // Maximum value not ignored by any of major browsers
+	return NewCors(allowedOrigins).Handler
+}
+
+// ReloadableCors is a CORS middleware whose allowed origins can be swapped at
+// runtime (e.g. on a SIGHUP config reload) without rebuilding the router.
+type ReloadableCors struct {
+	origins atomic.Pointer[[]string]
+}
+
+// NewCors builds a ReloadableCors seeded with allowedOrigins.
+func NewCors(allowedOrigins []string) *ReloadableCors {
+	rc := &ReloadableCors{}
+	rc.Set(allowedOrigins)
+	return rc
+}
+
+// Set replaces the allowed origins used by subsequent requests.
+func (rc *ReloadableCors) Set(allowedOrigins []string) {
+	origins := append([]string(nil), allowedOrigins...)
+	rc.origins.Store(&origins)
+}
+
+// Handler is the chi-compatible middleware constructor.
+func (rc *ReloadableCors) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cors.Handler(cors.Options{
+			AllowedOrigins:   *rc.origins.Load(),
+			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+			ExposedHeaders:   []string{"Link"},
+			AllowCredentials: true,
+			MaxAge:           300, // Maximum value not ignored by any of major browsers
+		})(next).ServeHTTP(w, r)
 	})
 }
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/travillian/tusk-horn/internal/pkg/logger"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyInProgress is the sentinel value stored under a key's Redis
+// entry while its handler is still running, so a concurrent request carrying
+// the same key can tell "already cached" apart from "in flight".
+const idempotencyInProgress = "in-progress"
+
+// idempotencyLockTTL bounds how long an in-progress reservation can block a
+// retry if the process that made it crashes before finishing.
+const idempotencyLockTTL = 30 * time.Second
+
+// idempotentResponse is the serialized form of a handler's response, cached
+// in Redis and replayed verbatim on a retried request.
+type idempotentResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// Idempotency replays a cached response for any POST/PUT/DELETE request that
+// repeats an Idempotency-Key header seen within ttl, so clients can safely
+// retry mutating requests without double-applying them. Requests without the
+// header, or that aren't mutating, pass through untouched.
+//
+// Only a successful (2xx) response is cached: pinning a transient 4xx/5xx
+// for the whole ttl would make the client's retry replay the failure instead
+// of actually re-executing. The key is also reserved before the handler
+// runs, so two concurrent requests with the same key can't both slip past
+// the cache check and double-apply the mutation; the loser gets a 409.
+func Idempotency(rdb *redis.Client, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutatingMethod(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := r.Header.Get(idempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			redisKey := "idempotency:" + key
+
+			if cached, err := rdb.Get(r.Context(), redisKey).Bytes(); err == nil {
+				if string(cached) == idempotencyInProgress {
+					http.Error(w, "A request with this Idempotency-Key is already in progress", http.StatusConflict)
+					return
+				}
+				var resp idempotentResponse
+				if err := json.Unmarshal(cached, &resp); err == nil {
+					replayResponse(w, resp)
+					return
+				}
+			}
+
+			reserved, err := rdb.SetNX(r.Context(), redisKey, idempotencyInProgress, idempotencyLockTTL).Result()
+			if err != nil {
+				// Fail open: a Redis outage shouldn't take the whole API down,
+				// it just means this key's requests aren't deduplicated.
+				logger.Log.Error("Failed to reserve idempotency key, allowing request", "key", key, "error", err)
+			} else if !reserved {
+				http.Error(w, "A request with this Idempotency-Key is already in progress", http.StatusConflict)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status < 200 || rec.status >= 300 {
+				// Release the reservation so a client retrying after a failure
+				// actually re-executes the handler instead of replaying it.
+				if err := rdb.Del(r.Context(), redisKey).Err(); err != nil {
+					logger.Log.Error("Failed to release idempotency key", "key", key, "error", err)
+				}
+				return
+			}
+
+			resp := idempotentResponse{StatusCode: rec.status, Header: w.Header().Clone(), Body: rec.body.Bytes()}
+			payload, err := json.Marshal(resp)
+			if err != nil {
+				return
+			}
+			if err := rdb.Set(r.Context(), redisKey, payload, ttl).Err(); err != nil {
+				logger.Log.Error("Failed to cache idempotent response", "key", key, "error", err)
+			}
+		})
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func replayResponse(w http.ResponseWriter, resp idempotentResponse) {
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.Header().Set("Idempotency-Replayed", "true")
+	w.WriteHeader(resp.StatusCode)
+	w.Write(resp.Body)
+}
+
+// responseRecorder captures a handler's status and body as they're written
+// so the response can be cached after the fact while still being sent to the
+// original caller unmodified.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
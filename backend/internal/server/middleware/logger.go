@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/travillian/tusk-horn/internal/pkg/logger"
+)
+
+type requestScopeContextKey struct{}
+
+// requestScope is a mutable holder attached to the request context before
+// the handler chain runs, so middleware/handlers further down the chain
+// (e.g. a Firebase auth check, or a handler that hits a domain error) can
+// report back to Logger's end-of-request access-log line even though they
+// only see a copy of *http.Request.
+type requestScope struct {
+	userID string
+	err    error
+}
+
+// SetUserID records the authenticated user's Firebase UID for the in-flight
+// request described by ctx, so Logger's access-log line includes it.
+func SetUserID(ctx context.Context, userID string) {
+	if s, ok := ctx.Value(requestScopeContextKey{}).(*requestScope); ok {
+		s.userID = userID
+	}
+}
+
+// SetRequestError records err as the error to include in Logger's
+// access-log line for the in-flight request described by ctx. Only the
+// first non-nil error recorded is kept.
+func SetRequestError(ctx context.Context, err error) {
+	if s, ok := ctx.Value(requestScopeContextKey{}).(*requestScope); ok && s.err == nil {
+		s.err = err
+	}
+}
+
+// Logger attaches a request-scoped slog.Logger carrying request_id,
+// trace_id, span_id, and remote_ip to the request context (retrievable via
+// logger.FromContext so handlers and repositories log with automatic
+// correlation), then emits a single access-log line once the handler
+// returns with method, path, status, bytes, duration, and - if set via
+// SetUserID/SetRequestError - user_id and error.
+func Logger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		scope := &requestScope{}
+		ctx := context.WithValue(r.Context(), requestScopeContextKey{}, scope)
+
+		reqLogger := logger.Log.With(
+			"request_id", chimiddleware.GetReqID(ctx),
+			"remote_ip", r.RemoteAddr,
+		)
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			reqLogger = reqLogger.With(
+				"trace_id", span.TraceID().String(),
+				"span_id", span.SpanID().String(),
+			)
+		}
+
+		ctx = logger.WithContext(ctx, reqLogger)
+		r = r.WithContext(ctx)
+
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		fields := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", ww.Status(),
+			"bytes", ww.BytesWritten(),
+			"duration_ms", time.Since(start).Milliseconds(),
+		}
+		if scope.userID != "" {
+			fields = append(fields, "user_id", scope.userID)
+		}
+		if scope.err != nil {
+			fields = append(fields, "error", scope.err.Error())
+		}
+
+		reqLogger.Info("request completed", fields...)
+	})
+}